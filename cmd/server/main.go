@@ -6,9 +6,14 @@ import (
 	"fmt"
 	"go-apibuilder/config"
 	"go-apibuilder/db/sqlc"
+	"go-apibuilder/internal/auth"
+	"go-apibuilder/internal/auth/oidc"
 	"go-apibuilder/internal/handler"
+	"go-apibuilder/internal/ratelimit"
 	"go-apibuilder/internal/repository"
 	"go-apibuilder/internal/service"
+	"go-apibuilder/internal/telemetry"
+	"go-apibuilder/internal/util"
 	"log"
 	"net/http"
 	"os"
@@ -32,6 +37,20 @@ func main() {
 
 	log.Printf("Configuration loaded successfully. App Env: %s, Server: %d", cfg.AppEnv, cfg.AppPort)
 
+	util.DefaultAlgorithm = cfg.PasswordHashAlgorithm
+
+	shutdownTracer, err := telemetry.InitTracer(context.Background(), "go-apibuilder", cfg.Telemetry.OTLPEndpoint)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracer: %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracer(ctx); err != nil {
+			log.Printf("Failed to shut down tracer: %v", err)
+		}
+	}()
+
 	dbPool, err := initDB(cfg.DbURL)
 	if err != nil {
 		log.Fatalf("Failed to initialize database: %v", err)
@@ -49,27 +68,52 @@ func main() {
 	sqlcQuerier := sqlc.New(dbPool)
 	log.Println("SQLC Querier initialized.")
 
-	userRepo := repository.NewDBUserRepository(sqlcQuerier)
+	userRepo := repository.Instrument(repository.NewDBUserRepository(sqlcQuerier))
 	log.Println("User repository initialized.")
 
 	// Initialize Services
-	userService := service.NewUserService(userRepo) // Example
+	userService := service.Instrument(service.NewUserService(userRepo)) // Example
 	log.Println("User service initialized.")
 
+	authService := auth.NewService(cfg, rdb, userRepo)
+	log.Println("Auth service initialized.")
+
+	loginLimiter := ratelimit.NewLoginLimiter(rdb, cfg.RateLimit.Auth)
+	ipLimiter := ratelimit.NewIPLimiter(rdb, cfg.RateLimit.IP.PerSecond(), cfg.RateLimit.IPBurst)
+	log.Println("Rate limiters initialized.")
+
 	// Initialize Gin router
 	if cfg.AppEnv == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	router := gin.Default()
+	router.Use(telemetry.GinMiddleware())
+	router.GET("/metrics", gin.WrapH(telemetry.Handler()))
 
 	// Initialize Handlers
 	userHandler := handler.NewUserHandler(userService)
 	log.Println("User handler initialized.")
 
+	authHandler := handler.NewAuthHandler(authService, loginLimiter)
+	log.Println("Auth handler initialized.")
+
 	// Setup routes
 	v1 := router.Group("/api/v1")
+	v1.Use(ratelimit.PerIP(ipLimiter))
 	{
-		app_router.SetupUserRoutes(v1, userHandler)
+		app_router.SetupUserRoutes(v1, userHandler, authService)
+		app_router.SetupAuthRoutes(v1, authHandler)
+
+		if len(cfg.OIDC) > 0 {
+			oidcProviders, err := oidc.NewManager(context.Background(), cfg.OIDC)
+			if err != nil {
+				log.Fatalf("Failed to initialize OIDC providers: %v", err)
+			}
+
+			oidcHandler := handler.NewOIDCHandler(oidcProviders, oidc.NewFlowStore(rdb), userRepo, authService)
+			app_router.SetupOIDCRoutes(v1, oidcHandler)
+			log.Println("OIDC login providers initialized.")
+		}
 	}
 
 	// Ping route for health check
@@ -82,6 +126,12 @@ func main() {
 		Handler: router,
 	}
 
+	// internal/transport/grpc is not wired in here yet: pkg/proto/user/v1
+	// only has the .proto source checked in, not the generated
+	// *.pb.go/*_grpc.pb.go stubs (run `make proto-gen` once buf and the
+	// protoc-gen-go plugins are available, then commit the output and
+	// start grpctransport.NewGRPCServer alongside srv below).
+
 	go func() {
 		log.Printf("Server listening on %d", cfg.AppPort)
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -118,6 +168,8 @@ func initDB(databaseURL string) (*pgxpool.Pool, error) {
 	// pgxpool_cfg.HealthCheckPeriod = time.Minute
 	// pgxpool_cfg.ConnConfig.ConnectTimeout = 5 * time.Second
 
+	pgxpoolCfg.ConnConfig.Tracer = telemetry.NewPgxTracer()
+
 	dbPool, err := pgxpool.NewWithConfig(context.Background(), pgxpoolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"go-apibuilder/internal/util"
+)
+
+// TestLoadConfig_PasswordHashAlgorithmRoundTrips guards against
+// PasswordHashAlgorithm's validate:"oneof=..." tag drifting out of sync
+// with the algorithm keys internal/util/password.go actually dispatches
+// on - a config value the validator accepts must also be one
+// util.HashPassword can hash with.
+func TestLoadConfig_PasswordHashAlgorithmRoundTrips(t *testing.T) {
+	for _, algorithm := range []string{"pbkdf2-sha256", "argon2id"} {
+		t.Run(algorithm, func(t *testing.T) {
+			t.Setenv("PASSWORD_HASH_ALGORITHM", algorithm)
+
+			cfg, err := LoadConfig()
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+
+			previous := util.DefaultAlgorithm
+			util.DefaultAlgorithm = cfg.PasswordHashAlgorithm
+			defer func() { util.DefaultAlgorithm = previous }()
+
+			hash, err := util.HashPassword("correct horse battery staple")
+			if err != nil {
+				t.Fatalf("HashPassword() error = %v, want nil for validated algorithm %q", err, cfg.PasswordHashAlgorithm)
+			}
+
+			ok, err := util.CheckPasswordHash("correct horse battery staple", hash)
+			if err != nil {
+				t.Fatalf("CheckPasswordHash() error = %v", err)
+			}
+			if !ok {
+				t.Fatal("CheckPasswordHash() = false, want true")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"go-apibuilder/internal/ratelimit"
+)
+
+// resolveString applies the precedence LoadConfig uses for every key: an
+// explicitly set env var always wins, then the config file's value if
+// present, then defaultValue.
+func resolveString(key string, fileVal *string, defaultValue string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	if fileVal != nil {
+		return *fileVal
+	}
+	return defaultValue
+}
+
+// resolveInt is resolveString for integer-valued keys. Unlike the old
+// flat loader, a present but unparsable env var is now an error rather
+// than a silent fallback to defaultValue - an operator who set
+// APP_PORT=abc almost certainly wants to know at startup, not at the
+// first failed bind.
+func resolveInt(key string, fileVal *int, defaultValue int) (int, error) {
+	if raw, ok := os.LookupEnv(key); ok {
+		v, err := strconv.Atoi(raw)
+		if err != nil {
+			return 0, &ConfigError{Key: key, Source: "env", Err: err}
+		}
+		return v, nil
+	}
+	if fileVal != nil {
+		return *fileVal, nil
+	}
+	return defaultValue, nil
+}
+
+// resolveDuration is resolveString for time.Duration keys (e.g. "30m",
+// "24h"), parsed from whichever of the env var or file override wins.
+func resolveDuration(key string, fileVal *string, defaultValue time.Duration) (time.Duration, error) {
+	raw, ok := os.LookupEnv(key)
+	source := "env"
+	if !ok {
+		if fileVal == nil {
+			return defaultValue, nil
+		}
+		raw, source = *fileVal, "file"
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, &ConfigError{Key: key, Source: source, Err: err}
+	}
+	return d, nil
+}
+
+// resolveSpec is resolveString for ratelimit.Spec keys (e.g. "5/30m").
+func resolveSpec(key string, fileVal *string, defaultValue string) (ratelimit.Spec, error) {
+	raw, ok := os.LookupEnv(key)
+	source := "env"
+	if !ok {
+		if fileVal != nil {
+			raw, source = *fileVal, "file"
+		} else {
+			raw, source = defaultValue, "default"
+		}
+	}
+
+	spec, err := ratelimit.ParseSpec(raw)
+	if err != nil {
+		return ratelimit.Spec{}, &ConfigError{Key: key, Source: source, Err: err}
+	}
+	return spec, nil
+}
@@ -0,0 +1,22 @@
+package config
+
+import "fmt"
+
+// ConfigError identifies a single configuration key that failed to load
+// or validate, and which layer it came from ("env" for an environment
+// variable, "file" for the CONFIG_FILE entry, "default" for a built-in
+// default value, or "validation" for a struct-tag failure), so an operator can tell at a glance whether to fix
+// APP_PORT=not-a-number or config.yaml's app_port.
+type ConfigError struct {
+	Key    string
+	Source string
+	Err    error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("config: invalid %s (source: %s): %v", e.Key, e.Source, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
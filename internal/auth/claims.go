@@ -0,0 +1,21 @@
+package auth
+
+import "github.com/golang-jwt/jwt/v5"
+
+// Claims is the JWT payload issued by Service.Login and Service.Refresh.
+// Subject holds the authenticated user's ID and ID (the standard "jti"
+// claim) binds the token to the Redis-backed session that RequireAuth
+// checks on every request.
+type Claims struct {
+	jwt.RegisteredClaims
+}
+
+// UserID returns the authenticated user's ID carried in the Subject claim.
+func (c Claims) UserID() string {
+	return c.Subject
+}
+
+// SessionID returns the session identifier carried in the ID ("jti") claim.
+func (c Claims) SessionID() string {
+	return c.ID
+}
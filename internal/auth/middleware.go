@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextUserIDKey is the gin context key RequireAuth stores the
+// authenticated user's ID under.
+const ContextUserIDKey = "auth.user_id"
+
+// RequireAuth is a Gin middleware that parses the Authorization bearer
+// token, verifies its signature and expiry, and rejects the request unless
+// its session is still alive in Redis - so a revoked (logged out) or
+// idle-expired session is refused even though the JWT itself hasn't
+// expired yet. On success it slides the session's idle timeout forward and
+// stores the user ID in the request context under ContextUserIDKey.
+func RequireAuth(service *Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, err := bearerToken(c.GetHeader("Authorization"))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		claims, err := service.Authenticate(c.Request.Context(), tokenString)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired session"})
+			return
+		}
+
+		c.Set(ContextUserIDKey, claims.UserID())
+		c.Next()
+	}
+}
+
+var errMissingBearerToken = errors.New("missing bearer token")
+
+func bearerToken(header string) (string, error) {
+	const prefix = "Bearer "
+	if !strings.HasPrefix(header, prefix) {
+		return "", errMissingBearerToken
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(header, prefix))
+	if token == "" {
+		return "", errMissingBearerToken
+	}
+
+	return token, nil
+}
@@ -0,0 +1,12 @@
+package oidc
+
+// ProviderConfig describes one configured OIDC provider, parsed from the
+// OIDC_PROVIDERS list and the OIDC_<NAME>_CLIENT_ID/SECRET/ISSUER/REDIRECT_URL
+// environment variables in config.LoadConfig.
+type ProviderConfig struct {
+	Name         string
+	ClientID     string
+	ClientSecret string
+	Issuer       string
+	RedirectURL  string
+}
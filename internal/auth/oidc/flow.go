@@ -0,0 +1,96 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/oauth2"
+)
+
+// ErrFlowNotFound indicates that a login flow's state parameter is unknown
+// or has expired - it may already have been used, timed out, or never
+// existed (e.g. a forged callback).
+var ErrFlowNotFound = errors.New("oidc flow not found")
+
+const flowKeyPrefix = "oidc_flow:"
+
+// flowTTL bounds how long a user has to complete the provider's login page
+// before the flow is considered abandoned.
+const flowTTL = 5 * time.Minute
+
+type flow struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// FlowStore persists the provider name and PKCE code verifier for an
+// in-progress login, keyed by the "state" parameter, so the callback can be
+// tied back to the login that started it without a server-side session.
+type FlowStore struct {
+	rdb *redis.Client
+}
+
+// NewFlowStore creates a FlowStore backed by the given Redis client.
+func NewFlowStore(rdb *redis.Client) *FlowStore {
+	return &FlowStore{rdb: rdb}
+}
+
+// Start generates a random state and PKCE code verifier, records them
+// together, and returns both so the caller can build the provider redirect
+// URL.
+func (s *FlowStore) Start(ctx context.Context, provider string) (state, codeVerifier string, err error) {
+	state, err = randomHex(32)
+	if err != nil {
+		return "", "", err
+	}
+
+	codeVerifier = oauth2.GenerateVerifier()
+
+	record, err := json.Marshal(flow{Provider: provider, CodeVerifier: codeVerifier})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode oidc flow: %w", err)
+	}
+
+	if err := s.rdb.Set(ctx, flowKeyPrefix+state, record, flowTTL).Err(); err != nil {
+		return "", "", fmt.Errorf("failed to store oidc flow: %w", err)
+	}
+
+	return state, codeVerifier, nil
+}
+
+// Consume looks up and deletes the flow for state, so a given state can
+// only be redeemed by one callback.
+func (s *FlowStore) Consume(ctx context.Context, state string) (provider, codeVerifier string, err error) {
+	key := flowKeyPrefix + state
+
+	raw, err := s.rdb.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return "", "", ErrFlowNotFound
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to look up oidc flow: %w", err)
+	}
+
+	_ = s.rdb.Del(ctx, key).Err()
+
+	var f flow
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return "", "", fmt.Errorf("failed to decode oidc flow: %w", err)
+	}
+
+	return f.Provider, f.CodeVerifier, nil
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random state: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
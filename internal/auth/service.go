@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go-apibuilder/config"
+	"go-apibuilder/internal/repository"
+	"go-apibuilder/internal/util"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidCredentials is returned by Login when the email is unknown or
+// the password does not match, without distinguishing which - callers must
+// not leak which one failed.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+// dummyPasswordHash is a valid argon2id hash with no known plaintext. Login
+// checks the submitted password against it when the email doesn't exist, so
+// a lookup miss costs the same CheckPasswordHash time as a wrong password
+// instead of returning early and leaking which emails are registered.
+const dummyPasswordHash = "argon2id:m=65536,t=3,p=2:/JJJ2gWa3pXZ8PJggVqMTg:vJzpAZUaGZuKR7nK3rDKJv+jMmBZ+QG+5G2/qddW6Ac"
+
+// Result is the outcome of a successful Login or Refresh: a signed JWT and
+// when it expires. Clients call /auth/refresh again once ExpiresAt is near
+// to keep their session alive.
+type Result struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Service implements the password-login JWT/session flow described in
+// internal/auth: it issues HS256 JWTs bound to a Redis-backed session so
+// that logout and idle timeouts can revoke an otherwise-unexpired token.
+type Service struct {
+	secretKey string
+	sessions  *sessionStore
+	users     repository.UserRepository
+}
+
+// NewService builds a Service from application configuration, a connected
+// Redis client, and the user repository used to verify credentials.
+func NewService(cfg *config.Config, rdb *redis.Client, users repository.UserRepository) *Service {
+	return &Service{
+		secretKey: cfg.SecretKey,
+		sessions:  newSessionStore(rdb, cfg.Auth.TokenIdleTimeout, cfg.Auth.TokenMaxLifetime),
+		users:     users,
+	}
+}
+
+// Login verifies email/password against the user repository and, on
+// success, starts a new session and returns a signed JWT for it. It also
+// transparently rehashes the stored password if it was hashed with an
+// algorithm other than util.DefaultAlgorithm, or with weaker parameters.
+func (s *Service) Login(ctx context.Context, email, password string) (*Result, error) {
+	user, err := s.users.GetUserByEmail(ctx, email)
+	if err != nil {
+		_, _ = util.CheckPasswordHash(password, dummyPasswordHash)
+		return nil, ErrInvalidCredentials
+	}
+
+	ok, err := util.CheckPasswordHash(password, user.HashedPassword)
+	if err != nil || !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	if _, err := s.users.RehashIfNeeded(ctx, user, password); err != nil {
+		return nil, fmt.Errorf("failed to rehash password: %w", err)
+	}
+
+	return s.startSession(ctx, strconv.FormatInt(user.ID, 10))
+}
+
+// Refresh rotates the session bound to tokenString's jti: it accepts an
+// expired-but-otherwise-valid JWT (that's the point of a refresh call),
+// confirms the session is still alive in Redis and within its absolute max
+// lifetime, then issues a new JWT under a new jti and revokes the old one.
+func (s *Service) Refresh(ctx context.Context, tokenString string) (*Result, error) {
+	claims, err := s.parseIgnoringExpiry(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.sessions.get(ctx, claims.SessionID())
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(record.IssuedAt) >= s.sessions.maxLifetime {
+		_ = s.sessions.delete(ctx, claims.SessionID())
+		return nil, ErrSessionNotFound
+	}
+
+	if err := s.sessions.delete(ctx, claims.SessionID()); err != nil {
+		return nil, err
+	}
+
+	return s.continueSession(ctx, record.UserID, record.IssuedAt)
+}
+
+// Logout revokes the session bound to tokenString's jti. It is deliberately
+// lenient about the JWT's own expiry - you should be able to log out with a
+// token that has just expired.
+func (s *Service) Logout(ctx context.Context, tokenString string) error {
+	claims, err := s.parseIgnoringExpiry(tokenString)
+	if err != nil {
+		return err
+	}
+
+	return s.sessions.delete(ctx, claims.SessionID())
+}
+
+// startSession begins a brand new session (fresh issued-at, used by Login).
+func (s *Service) startSession(ctx context.Context, userID string) (*Result, error) {
+	return s.continueSession(ctx, userID, time.Now())
+}
+
+// IssueSession starts a new session for userID without verifying a
+// password, for login paths that authenticate by some other means (e.g.
+// internal/auth/oidc) but still want the same JWT/session handed to
+// RequireAuth.
+func (s *Service) IssueSession(ctx context.Context, userID string) (*Result, error) {
+	return s.startSession(ctx, userID)
+}
+
+// continueSession issues a fresh JWT/jti for userID, preserving issuedAt so
+// the absolute max lifetime is measured from the original login rather than
+// from each refresh.
+func (s *Service) continueSession(ctx context.Context, userID string, issuedAt time.Time) (*Result, error) {
+	jti, err := newJTI()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sessions.create(ctx, jti, userID, issuedAt); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	token, err := s.issue(userID, jti, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Token: token, ExpiresAt: now.Add(tokenTTL)}, nil
+}
+
+// Authenticate verifies tokenString and that its session is still alive,
+// sliding the session's idle timeout forward. It is the check behind the
+// RequireAuth middleware.
+func (s *Service) Authenticate(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.sessions.touch(ctx, claims.SessionID()); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// parseIgnoringExpiry parses and verifies a JWT's signature while
+// tolerating an expired exp claim, for the refresh and logout flows which
+// are expected to be called with a token that has just expired.
+func (s *Service) parseIgnoringExpiry(tokenString string) (*Claims, error) {
+	claims, err := s.parse(tokenString)
+	if err == nil {
+		return claims, nil
+	}
+
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return claims, nil
+	}
+
+	return nil, err
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-apibuilder/config"
+	"go-apibuilder/db/sqlc"
+	"go-apibuilder/internal/repository"
+	"go-apibuilder/internal/util"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// fakeUserRepository is a minimal repository.UserRepository backed by a map,
+// implementing only what Service actually calls (GetUserByEmail,
+// RehashIfNeeded); the rest panic so a test that exercises them fails loudly
+// instead of silently returning zero values.
+type fakeUserRepository struct {
+	usersByEmail map[string]sqlc.User
+	rehashCalls  int
+}
+
+var _ repository.UserRepository = (*fakeUserRepository)(nil)
+
+func (f *fakeUserRepository) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+	panic("not used by these tests")
+}
+
+func (f *fakeUserRepository) DeleteUser(ctx context.Context, id int64) error {
+	panic("not used by these tests")
+}
+
+func (f *fakeUserRepository) GetUserByID(ctx context.Context, id int64) (sqlc.User, error) {
+	panic("not used by these tests")
+}
+
+func (f *fakeUserRepository) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+	user, ok := f.usersByEmail[email]
+	if !ok {
+		return sqlc.User{}, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (f *fakeUserRepository) ListUsers(ctx context.Context, arg sqlc.ListUsersParams) ([]sqlc.User, error) {
+	panic("not used by these tests")
+}
+
+func (f *fakeUserRepository) UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.User, error) {
+	panic("not used by these tests")
+}
+
+func (f *fakeUserRepository) RehashIfNeeded(ctx context.Context, user sqlc.User, password string) (sqlc.User, error) {
+	f.rehashCalls++
+	return user, nil
+}
+
+func newTestServiceWithUsers(t *testing.T, idleTimeout, maxLifetime time.Duration, users *fakeUserRepository) *Service {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	cfg := &config.Config{
+		SecretKey: "test-secret-key",
+		Auth: config.AuthConfig{
+			TokenIdleTimeout: idleTimeout,
+			TokenMaxLifetime: maxLifetime,
+		},
+	}
+
+	return NewService(cfg, rdb, users)
+}
+
+func userWithPassword(t *testing.T, id int64, email, password string) sqlc.User {
+	t.Helper()
+
+	hash, err := util.HashPassword(password)
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	return sqlc.User{ID: id, Email: email, HashedPassword: hash}
+}
+
+func TestService_Login_Success(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepository{usersByEmail: map[string]sqlc.User{
+		"alice@example.com": userWithPassword(t, 1, "alice@example.com", "correct horse battery staple"),
+	}}
+	service := newTestServiceWithUsers(t, time.Hour, 24*time.Hour, users)
+
+	result, err := service.Login(ctx, "alice@example.com", "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if result.Token == "" {
+		t.Fatal("Login() Token = \"\", want a signed JWT")
+	}
+	if users.rehashCalls != 1 {
+		t.Fatalf("RehashIfNeeded call count = %d, want 1", users.rehashCalls)
+	}
+
+	if _, err := service.Authenticate(ctx, result.Token); err != nil {
+		t.Fatalf("Authenticate(result.Token) error = %v, want nil: login should have started a live session", err)
+	}
+}
+
+func TestService_Login_UnknownEmail(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepository{usersByEmail: map[string]sqlc.User{}}
+	service := newTestServiceWithUsers(t, time.Hour, 24*time.Hour, users)
+
+	if _, err := service.Login(ctx, "nobody@example.com", "whatever"); err != ErrInvalidCredentials {
+		t.Fatalf("Login() error = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestService_Login_WrongPassword(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepository{usersByEmail: map[string]sqlc.User{
+		"alice@example.com": userWithPassword(t, 1, "alice@example.com", "correct horse battery staple"),
+	}}
+	service := newTestServiceWithUsers(t, time.Hour, 24*time.Hour, users)
+
+	if _, err := service.Login(ctx, "alice@example.com", "wrong password"); err != ErrInvalidCredentials {
+		t.Fatalf("Login() error = %v, want ErrInvalidCredentials", err)
+	}
+}
+
+func TestService_Authenticate_RejectsRevokedSession(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepository{usersByEmail: map[string]sqlc.User{
+		"alice@example.com": userWithPassword(t, 1, "alice@example.com", "correct horse battery staple"),
+	}}
+	service := newTestServiceWithUsers(t, time.Hour, 24*time.Hour, users)
+
+	result, err := service.Login(ctx, "alice@example.com", "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	if err := service.Logout(ctx, result.Token); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+
+	if _, err := service.Authenticate(ctx, result.Token); err != ErrSessionNotFound {
+		t.Fatalf("Authenticate() after Logout() error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestService_Refresh_RotatesSession(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepository{usersByEmail: map[string]sqlc.User{
+		"alice@example.com": userWithPassword(t, 1, "alice@example.com", "correct horse battery staple"),
+	}}
+	service := newTestServiceWithUsers(t, time.Hour, 24*time.Hour, users)
+
+	first, err := service.Login(ctx, "alice@example.com", "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	second, err := service.Refresh(ctx, first.Token)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if second.Token == first.Token {
+		t.Fatal("Refresh() returned the same token, want a newly issued one")
+	}
+
+	if _, err := service.Authenticate(ctx, first.Token); err != ErrSessionNotFound {
+		t.Fatalf("Authenticate(first.Token) after Refresh() error = %v, want ErrSessionNotFound: the old jti must be revoked", err)
+	}
+	if _, err := service.Authenticate(ctx, second.Token); err != nil {
+		t.Fatalf("Authenticate(second.Token) error = %v, want nil", err)
+	}
+}
+
+func TestService_Refresh_ExpiredAtMaxLifetime(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepository{usersByEmail: map[string]sqlc.User{
+		"alice@example.com": userWithPassword(t, 1, "alice@example.com", "correct horse battery staple"),
+	}}
+	service := newTestServiceWithUsers(t, time.Hour, 30*time.Millisecond, users)
+
+	result, err := service.Login(ctx, "alice@example.com", "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if _, err := service.Refresh(ctx, result.Token); err != ErrSessionNotFound {
+		t.Fatalf("Refresh() past max lifetime error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestService_Logout_RevokesSession(t *testing.T) {
+	ctx := context.Background()
+	users := &fakeUserRepository{usersByEmail: map[string]sqlc.User{
+		"alice@example.com": userWithPassword(t, 1, "alice@example.com", "correct horse battery staple"),
+	}}
+	service := newTestServiceWithUsers(t, time.Hour, 24*time.Hour, users)
+
+	result, err := service.Login(ctx, "alice@example.com", "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	if err := service.Logout(ctx, result.Token); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := service.Authenticate(ctx, result.Token); err != ErrSessionNotFound {
+		t.Fatalf("Authenticate() after Logout() error = %v, want ErrSessionNotFound", err)
+	}
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrSessionNotFound indicates that a session's Redis key is missing or has
+// expired - either the idle timeout elapsed, it was logged out, or it was
+// rotated away by a refresh.
+var ErrSessionNotFound = errors.New("session not found")
+
+const sessionKeyPrefix = "session:"
+
+// sessionRecord is the JSON value stored at session:{jti}. issuedAt is kept
+// alongside userID so touch can cap the sliding idle timeout at the
+// session's absolute max lifetime without a second Redis key.
+type sessionRecord struct {
+	UserID   string    `json:"user_id"`
+	IssuedAt time.Time `json:"issued_at"`
+}
+
+// sessionStore persists the set of live sessions in Redis, keyed by JTI.
+// Presence of the key is the source of truth for whether a JTI is still
+// valid, and its TTL implements the sliding idle timeout.
+type sessionStore struct {
+	rdb         *redis.Client
+	idleTimeout time.Duration
+	maxLifetime time.Duration
+}
+
+func newSessionStore(rdb *redis.Client, idleTimeout, maxLifetime time.Duration) *sessionStore {
+	return &sessionStore{rdb: rdb, idleTimeout: idleTimeout, maxLifetime: maxLifetime}
+}
+
+func sessionKey(jti string) string {
+	return sessionKeyPrefix + jti
+}
+
+// create records a new session, valid until the idle timeout elapses.
+func (s *sessionStore) create(ctx context.Context, jti, userID string, issuedAt time.Time) error {
+	record, err := json.Marshal(sessionRecord{UserID: userID, IssuedAt: issuedAt})
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	if err := s.rdb.Set(ctx, sessionKey(jti), record, s.idleTimeout).Err(); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// touch slides the session's idle timeout forward, capped so the session
+// never outlives its absolute max lifetime. It returns ErrSessionNotFound
+// if the session is revoked, idle-expired, or has hit its max lifetime.
+func (s *sessionStore) touch(ctx context.Context, jti string) (*sessionRecord, error) {
+	record, err := s.get(ctx, jti)
+	if err != nil {
+		return nil, err
+	}
+
+	remainingLifetime := time.Until(record.IssuedAt.Add(s.maxLifetime))
+	if remainingLifetime <= 0 {
+		_ = s.delete(ctx, jti)
+		return nil, ErrSessionNotFound
+	}
+
+	ttl := s.idleTimeout
+	if remainingLifetime < ttl {
+		ttl = remainingLifetime
+	}
+
+	ok, err := s.rdb.Expire(ctx, sessionKey(jti), ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh session: %w", err)
+	}
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	return record, nil
+}
+
+// get returns the record for a session, or ErrSessionNotFound if the
+// session is revoked or idle-expired.
+func (s *sessionStore) get(ctx context.Context, jti string) (*sessionRecord, error) {
+	raw, err := s.rdb.Get(ctx, sessionKey(jti)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up session: %w", err)
+	}
+
+	var record sessionRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	return &record, nil
+}
+
+// delete revokes a session immediately, used by logout and by refresh when
+// rotating to a new JTI.
+func (s *sessionStore) delete(ctx context.Context, jti string) error {
+	if err := s.rdb.Del(ctx, sessionKey(jti)).Err(); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestSessionStore(t *testing.T, idleTimeout, maxLifetime time.Duration) (*sessionStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return newSessionStore(rdb, idleTimeout, maxLifetime), mr
+}
+
+func TestSessionStore_CreateAndGet(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestSessionStore(t, time.Hour, 24*time.Hour)
+	issuedAt := time.Now()
+
+	if err := store.create(ctx, "jti-1", "user-1", issuedAt); err != nil {
+		t.Fatalf("create() error = %v", err)
+	}
+
+	record, err := store.get(ctx, "jti-1")
+	if err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if record.UserID != "user-1" {
+		t.Fatalf("get() UserID = %q, want %q", record.UserID, "user-1")
+	}
+	if !record.IssuedAt.Equal(issuedAt) {
+		t.Fatalf("get() IssuedAt = %v, want %v", record.IssuedAt, issuedAt)
+	}
+}
+
+func TestSessionStore_GetMissing(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestSessionStore(t, time.Hour, 24*time.Hour)
+
+	if _, err := store.get(ctx, "no-such-jti"); err != ErrSessionNotFound {
+		t.Fatalf("get() error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestSessionStore_GetIdleExpired(t *testing.T) {
+	ctx := context.Background()
+	store, mr := newTestSessionStore(t, time.Minute, time.Hour)
+
+	if err := store.create(ctx, "jti-1", "user-1", time.Now()); err != nil {
+		t.Fatalf("create() error = %v", err)
+	}
+
+	mr.FastForward(time.Minute + time.Second)
+
+	if _, err := store.get(ctx, "jti-1"); err != ErrSessionNotFound {
+		t.Fatalf("get() after idle timeout error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestSessionStore_TouchSlidesIdleTimeout(t *testing.T) {
+	ctx := context.Background()
+	store, mr := newTestSessionStore(t, time.Minute, time.Hour)
+
+	if err := store.create(ctx, "jti-1", "user-1", time.Now()); err != nil {
+		t.Fatalf("create() error = %v", err)
+	}
+
+	mr.FastForward(45 * time.Second)
+	if _, err := store.touch(ctx, "jti-1"); err != nil {
+		t.Fatalf("touch() error = %v", err)
+	}
+
+	// Without the touch above, the idle timeout set at create() would have
+	// expired by now; touch should have slid it another full minute out.
+	mr.FastForward(45 * time.Second)
+	if _, err := store.get(ctx, "jti-1"); err != nil {
+		t.Fatalf("get() after touch error = %v, want nil: touch should have slid the idle timeout forward", err)
+	}
+}
+
+func TestSessionStore_TouchCapsAtMaxLifetime(t *testing.T) {
+	ctx := context.Background()
+	// Redis EXPIRE only has second-level granularity, so the idle timeout
+	// and max lifetime here are seconds apart rather than milliseconds.
+	store, mr := newTestSessionStore(t, time.Hour, 3*time.Second)
+	issuedAt := time.Now()
+
+	if err := store.create(ctx, "jti-1", "user-1", issuedAt); err != nil {
+		t.Fatalf("create() error = %v", err)
+	}
+
+	mr.FastForward(2 * time.Second)
+	if _, err := store.touch(ctx, "jti-1"); err != nil {
+		t.Fatalf("touch() before max lifetime error = %v", err)
+	}
+
+	ttl, err := store.rdb.TTL(ctx, sessionKey("jti-1")).Result()
+	if err != nil {
+		t.Fatalf("TTL() error = %v", err)
+	}
+	if ttl <= 0 || ttl > 2*time.Second {
+		t.Fatalf("TTL() = %v, want a TTL capped to roughly the remaining 1s of max lifetime, not the full 1h idle timeout", ttl)
+	}
+
+	mr.FastForward(2 * time.Second)
+	if _, err := store.touch(ctx, "jti-1"); err != ErrSessionNotFound {
+		t.Fatalf("touch() past max lifetime error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestSessionStore_Delete(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestSessionStore(t, time.Hour, 24*time.Hour)
+
+	if err := store.create(ctx, "jti-1", "user-1", time.Now()); err != nil {
+		t.Fatalf("create() error = %v", err)
+	}
+	if err := store.delete(ctx, "jti-1"); err != nil {
+		t.Fatalf("delete() error = %v", err)
+	}
+	if _, err := store.get(ctx, "jti-1"); err != ErrSessionNotFound {
+		t.Fatalf("get() after delete error = %v, want ErrSessionNotFound", err)
+	}
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken indicates a bearer token that is malformed, has an
+// unexpected signing method, or failed signature verification.
+var ErrInvalidToken = errors.New("invalid token")
+
+// tokenTTL is how long a signed JWT itself is valid for. It is intentionally
+// shorter than the idle timeout: expiry of the JWT just forces a refresh
+// call, while the Redis-backed session is what actually tracks whether the
+// session is still alive.
+const tokenTTL = 15 * time.Minute
+
+func newJTI() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate jti: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// issue signs a new JWT for userID bound to jti, valid for tokenTTL.
+func (s *Service) issue(userID, jti string, issuedAt time.Time) (string, error) {
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userID,
+			ID:        jti,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(tokenTTL)),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(s.secretKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signed, nil
+}
+
+// parse verifies a JWT's signature and expiry and returns its claims. It
+// does not check the Redis-backed session - callers that care whether the
+// session itself is still alive should follow up with sessionStore.get or
+// touch.
+func (s *Service) parse(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("%w: unexpected signing method %v", ErrInvalidToken, t.Header["alg"])
+		}
+		return []byte(s.secretKey), nil
+	})
+	if err != nil {
+		return claims, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if !token.Valid {
+		return claims, ErrInvalidToken
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-apibuilder/internal/auth"
+	"go-apibuilder/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler exposes the login/refresh/logout HTTP endpoints on top of
+// auth.Service, translating its errors into the appropriate status codes.
+type AuthHandler struct {
+	authService  *auth.Service
+	loginLimiter *ratelimit.LoginLimiter
+}
+
+// NewAuthHandler creates an AuthHandler backed by the given auth.Service,
+// rate-limiting login attempts per email via loginLimiter.
+func NewAuthHandler(authService *auth.Service, loginLimiter *ratelimit.LoginLimiter) *AuthHandler {
+	return &AuthHandler{authService: authService, loginLimiter: loginLimiter}
+}
+
+type loginRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// Login handles POST /auth/login.
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req loginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	allowed, retryAfter, err := h.loginLimiter.Allow(c.Request.Context(), req.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check rate limit"})
+		return
+	}
+	if !allowed {
+		c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "too many login attempts, try again later"})
+		return
+	}
+
+	result, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log in"})
+		return
+	}
+
+	if err := h.loginLimiter.Reset(c.Request.Context(), req.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reset rate limit"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+type refreshRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// Refresh handles POST /auth/refresh.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req refreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.authService.Refresh(c.Request.Context(), req.Token)
+	if err != nil {
+		if errors.Is(err, auth.ErrSessionNotFound) || errors.Is(err, auth.ErrInvalidToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired session"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to refresh session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+type logoutRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// Logout handles POST /auth/logout.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req logoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authService.Logout(c.Request.Context(), req.Token); err != nil {
+		if errors.Is(err, auth.ErrSessionNotFound) || errors.Is(err, auth.ErrInvalidToken) {
+			c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
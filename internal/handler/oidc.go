@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go-apibuilder/db/sqlc"
+	"go-apibuilder/internal/auth"
+	"go-apibuilder/internal/auth/oidc"
+	"go-apibuilder/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OIDCHandler drives the "login with <provider>" flow: redirecting to the
+// provider, then on callback exchanging the code, verifying the ID token,
+// resolving it to a local user by email, and issuing the same JWT session
+// the password login path issues so downstream middleware is unchanged.
+type OIDCHandler struct {
+	providers   *oidc.Manager
+	flows       *oidc.FlowStore
+	userRepo    repository.UserRepository
+	authService *auth.Service
+}
+
+// NewOIDCHandler creates an OIDCHandler for the given configured providers.
+func NewOIDCHandler(providers *oidc.Manager, flows *oidc.FlowStore, userRepo repository.UserRepository, authService *auth.Service) *OIDCHandler {
+	return &OIDCHandler{providers: providers, flows: flows, userRepo: userRepo, authService: authService}
+}
+
+// Login handles GET /auth/oidc/:provider/login.
+func (h *OIDCHandler) Login(c *gin.Context) {
+	name := c.Param("provider")
+	provider, ok := h.providers.Provider(name)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown provider"})
+		return
+	}
+
+	state, codeVerifier, err := h.flows.Start(c.Request.Context(), name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start login"})
+		return
+	}
+
+	c.Redirect(http.StatusFound, provider.AuthCodeURL(state, codeVerifier))
+}
+
+type oidcClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// Callback handles GET /auth/oidc/:provider/callback.
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	name := c.Param("provider")
+	provider, ok := h.providers.Provider(name)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown provider"})
+		return
+	}
+
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing state or code"})
+		return
+	}
+
+	flowProvider, codeVerifier, err := h.flows.Consume(c.Request.Context(), state)
+	if err != nil || flowProvider != name {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired login"})
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	idToken, err := provider.Exchange(ctx, code, codeVerifier)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "failed to complete login"})
+		return
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil || claims.Email == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "id token did not include an email"})
+		return
+	}
+	if !claims.EmailVerified {
+		// An unverified email can't be trusted to resolve to (or provision)
+		// a local account: some issuers let a caller claim any address.
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "id token email is not verified"})
+		return
+	}
+
+	user, err := h.userRepo.GetUserByEmail(ctx, claims.Email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		user, err = h.provisionUser(ctx, claims.Email)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to provision user"})
+			return
+		}
+	} else if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to look up user"})
+		return
+	}
+
+	result, err := h.authService.IssueSession(ctx, strconv.FormatInt(user.ID, 10))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// provisionUser creates a new local user the first time an email is seen
+// via OIDC, with a random password hash that cannot be used to log in
+// through the password flow.
+func (h *OIDCHandler) provisionUser(ctx context.Context, email string) (sqlc.User, error) {
+	randomPassword, err := randomUnusablePassword()
+	if err != nil {
+		return sqlc.User{}, err
+	}
+
+	return h.userRepo.CreateUser(ctx, sqlc.CreateUserParams{
+		Email:          email,
+		HashedPassword: randomPassword,
+	})
+}
+
+func randomUnusablePassword() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random password: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
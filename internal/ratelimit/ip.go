@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const ipBucketKeyPrefix = "ratelimit:ip:"
+
+// ipBucketScript implements a token bucket atomically in Redis: refill
+// tokens based on elapsed time since the bucket was last touched, then try
+// to take one token. KEYS[1] is the bucket key; ARGV is rate (tokens/sec),
+// burst (bucket capacity), and the current unix time in milliseconds.
+// Returns 1 if the request is allowed, 0 otherwise.
+var ipBucketScript = redis.NewScript(`
+local key = KEYS[1]
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", key, "tokens", "updated_at")
+local tokens = tonumber(bucket[1])
+local updatedAt = tonumber(bucket[2])
+
+if tokens == nil then
+  tokens = burst
+  updatedAt = now
+end
+
+local elapsedSeconds = math.max(0, now - updatedAt) / 1000
+tokens = math.min(burst, tokens + elapsedSeconds * rate)
+
+local allowed = 0
+if tokens >= 1 then
+  allowed = 1
+  tokens = tokens - 1
+end
+
+redis.call("HMSET", key, "tokens", tokens, "updated_at", now)
+redis.call("PEXPIRE", key, math.ceil((burst / rate) * 1000))
+
+return allowed
+`)
+
+// IPLimiter is a Redis-backed token-bucket limiter, one bucket per key
+// (typically the client IP), refilling at rate tokens per second up to
+// burst capacity.
+type IPLimiter struct {
+	rdb   *redis.Client
+	rate  float64
+	burst int
+}
+
+// NewIPLimiter builds an IPLimiter that allows up to burst requests at
+// once and refills at rate tokens per second thereafter.
+func NewIPLimiter(rdb *redis.Client, rate float64, burst int) *IPLimiter {
+	return &IPLimiter{rdb: rdb, rate: rate, burst: burst}
+}
+
+// Allow reports whether a request identified by key (typically the client
+// IP) is within the bucket's rate, consuming a token if so.
+func (l *IPLimiter) Allow(ctx context.Context, key string, nowUnixMilli int64) (bool, error) {
+	result, err := ipBucketScript.Run(ctx, l.rdb, []string{ipBucketKeyPrefix + key}, l.rate, l.burst, nowUnixMilli).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate rate limit: %w", err)
+	}
+	return result == 1, nil
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestIPLimiter(t *testing.T, rate float64, burst int) *IPLimiter {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return NewIPLimiter(rdb, rate, burst)
+}
+
+func TestIPLimiter_AllowsUpToBurst(t *testing.T) {
+	ctx := context.Background()
+	limiter := newTestIPLimiter(t, 1, 3)
+
+	const now = int64(1_700_000_000_000) // fake clock: every call happens at the same instant
+
+	for i := 1; i <= 3; i++ {
+		allowed, err := limiter.Allow(ctx, "1.2.3.4", now)
+		if err != nil {
+			t.Fatalf("Allow() request %d error = %v", i, err)
+		}
+		if !allowed {
+			t.Fatalf("Allow() request %d = false, want true (within burst)", i)
+		}
+	}
+
+	allowed, err := limiter.Allow(ctx, "1.2.3.4", now)
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if allowed {
+		t.Fatal("Allow() = true after exhausting the burst at a single instant, want false")
+	}
+}
+
+func TestIPLimiter_RefillsOverTime(t *testing.T) {
+	ctx := context.Background()
+	limiter := newTestIPLimiter(t, 1, 1) // 1 token/sec, burst of 1
+
+	const start = int64(1_700_000_000_000)
+
+	if allowed, err := limiter.Allow(ctx, "1.2.3.4", start); err != nil || !allowed {
+		t.Fatalf("Allow() at start = (%v, %v), want (true, nil)", allowed, err)
+	}
+	if allowed, err := limiter.Allow(ctx, "1.2.3.4", start+100); err != nil || allowed {
+		t.Fatalf("Allow() 100ms later = (%v, %v), want (false, nil): bucket should still be empty", allowed, err)
+	}
+
+	// Fake clock advanced a full second: exactly one token should have refilled.
+	if allowed, err := limiter.Allow(ctx, "1.2.3.4", start+1_000); err != nil || !allowed {
+		t.Fatalf("Allow() 1s later = (%v, %v), want (true, nil): a token should have refilled", allowed, err)
+	}
+	if allowed, err := limiter.Allow(ctx, "1.2.3.4", start+1_000); err != nil || allowed {
+		t.Fatalf("Allow() immediately after = (%v, %v), want (false, nil)", allowed, err)
+	}
+}
+
+func TestIPLimiter_TracksKeysIndependently(t *testing.T) {
+	ctx := context.Background()
+	limiter := newTestIPLimiter(t, 1, 1)
+
+	const now = int64(1_700_000_000_000)
+
+	if allowed, err := limiter.Allow(ctx, "1.2.3.4", now); err != nil || !allowed {
+		t.Fatalf("Allow(1.2.3.4) = (%v, %v), want (true, nil)", allowed, err)
+	}
+	if allowed, err := limiter.Allow(ctx, "1.2.3.4", now); err != nil || allowed {
+		t.Fatalf("Allow(1.2.3.4) second call = (%v, %v), want (false, nil)", allowed, err)
+	}
+	if allowed, err := limiter.Allow(ctx, "5.6.7.8", now); err != nil || !allowed {
+		t.Fatalf("Allow(5.6.7.8) = (%v, %v), want (true, nil): a different key must have its own bucket", allowed, err)
+	}
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const loginAttemptsKeyPrefix = "login_attempts:"
+
+// LoginLimiter enforces Spec login attempts per identity (typically an
+// email) within Spec.Period, using INCR+EXPIRE on a Redis key so it works
+// the same way across every app instance.
+type LoginLimiter struct {
+	rdb  *redis.Client
+	spec Spec
+}
+
+// NewLoginLimiter builds a LoginLimiter with the given limit.
+func NewLoginLimiter(rdb *redis.Client, spec Spec) *LoginLimiter {
+	return &LoginLimiter{rdb: rdb, spec: spec}
+}
+
+// Allow records an attempt for identity and reports whether it is within
+// the limit. When the limit has been exceeded, retryAfter is how long the
+// caller should wait before trying again.
+func (l *LoginLimiter) Allow(ctx context.Context, identity string) (allowed bool, retryAfter time.Duration, err error) {
+	key := loginAttemptsKeyPrefix + identity
+
+	count, err := l.rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to increment login attempts: %w", err)
+	}
+
+	if count == 1 {
+		if err := l.rdb.Expire(ctx, key, l.spec.Period).Err(); err != nil {
+			return false, 0, fmt.Errorf("failed to set login attempts expiry: %w", err)
+		}
+	}
+
+	if count <= int64(l.spec.Count) {
+		return true, 0, nil
+	}
+
+	ttl, err := l.rdb.TTL(ctx, key).Result()
+	if err != nil || ttl < 0 {
+		ttl = l.spec.Period
+	}
+
+	return false, ttl, nil
+}
+
+// Reset clears identity's attempt count, called after a successful login so
+// a legitimate user isn't penalized by earlier failed attempts.
+func (l *LoginLimiter) Reset(ctx context.Context, identity string) error {
+	if err := l.rdb.Del(ctx, loginAttemptsKeyPrefix+identity).Err(); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
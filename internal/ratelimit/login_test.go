@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestLoginLimiter(t *testing.T, spec Spec) *LoginLimiter {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return NewLoginLimiter(rdb, spec)
+}
+
+func TestLoginLimiter_AllowsUpToCount(t *testing.T) {
+	ctx := context.Background()
+	limiter := newTestLoginLimiter(t, Spec{Count: 3, Period: 30 * time.Minute})
+
+	for i := 1; i <= 3; i++ {
+		allowed, retryAfter, err := limiter.Allow(ctx, "alice@example.com")
+		if err != nil {
+			t.Fatalf("Allow() attempt %d error = %v", i, err)
+		}
+		if !allowed {
+			t.Fatalf("Allow() attempt %d = false, want true (within limit)", i)
+		}
+		if retryAfter != 0 {
+			t.Fatalf("Allow() attempt %d retryAfter = %v, want 0", i, retryAfter)
+		}
+	}
+}
+
+func TestLoginLimiter_BlocksOverCount(t *testing.T) {
+	ctx := context.Background()
+	limiter := newTestLoginLimiter(t, Spec{Count: 2, Period: 30 * time.Minute})
+
+	for i := 1; i <= 2; i++ {
+		if allowed, _, err := limiter.Allow(ctx, "alice@example.com"); err != nil || !allowed {
+			t.Fatalf("Allow() attempt %d = (%v, %v), want (true, nil)", i, allowed, err)
+		}
+	}
+
+	allowed, retryAfter, err := limiter.Allow(ctx, "alice@example.com")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if allowed {
+		t.Fatal("Allow() = true after exceeding the count, want false")
+	}
+	if retryAfter <= 0 {
+		t.Fatalf("Allow() retryAfter = %v, want > 0", retryAfter)
+	}
+}
+
+func TestLoginLimiter_TracksIdentitiesIndependently(t *testing.T) {
+	ctx := context.Background()
+	limiter := newTestLoginLimiter(t, Spec{Count: 1, Period: 30 * time.Minute})
+
+	if allowed, _, err := limiter.Allow(ctx, "alice@example.com"); err != nil || !allowed {
+		t.Fatalf("Allow(alice) = (%v, %v), want (true, nil)", allowed, err)
+	}
+	if allowed, _, err := limiter.Allow(ctx, "alice@example.com"); err != nil || allowed {
+		t.Fatalf("Allow(alice) second call = (%v, %v), want (false, nil)", allowed, err)
+	}
+	if allowed, _, err := limiter.Allow(ctx, "bob@example.com"); err != nil || !allowed {
+		t.Fatalf("Allow(bob) = (%v, %v), want (true, nil): a blocked identity must not affect others", allowed, err)
+	}
+}
+
+func TestLoginLimiter_ResetClearsCount(t *testing.T) {
+	ctx := context.Background()
+	limiter := newTestLoginLimiter(t, Spec{Count: 1, Period: 30 * time.Minute})
+
+	if allowed, _, err := limiter.Allow(ctx, "alice@example.com"); err != nil || !allowed {
+		t.Fatalf("Allow() = (%v, %v), want (true, nil)", allowed, err)
+	}
+	if allowed, _, err := limiter.Allow(ctx, "alice@example.com"); err != nil || allowed {
+		t.Fatalf("Allow() second call = (%v, %v), want (false, nil)", allowed, err)
+	}
+
+	if err := limiter.Reset(ctx, "alice@example.com"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	if allowed, _, err := limiter.Allow(ctx, "alice@example.com"); err != nil || !allowed {
+		t.Fatalf("Allow() after Reset() = (%v, %v), want (true, nil)", allowed, err)
+	}
+}
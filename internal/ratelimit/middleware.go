@@ -0,0 +1,29 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PerIP is a Gin middleware that applies limiter to every request, keyed by
+// the client's IP. It is meant to be installed on the whole /api/v1 group.
+func PerIP(limiter *IPLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, err := limiter.Allow(c.Request.Context(), c.ClientIP(), time.Now().UnixMilli())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to check rate limit"})
+			return
+		}
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(1/limiter.rate)+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}
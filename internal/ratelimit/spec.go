@@ -0,0 +1,42 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Spec is a parsed "N/duration" rate limit, e.g. "100/1m" or "5/30m". It is
+// shared by the login attempt limiter and the per-IP limiter so both read
+// the same config syntax.
+type Spec struct {
+	Count  int
+	Period time.Duration
+}
+
+// ParseSpec parses a "N/duration" string such as "5/30m" or "100/1m".
+func ParseSpec(s string) (Spec, error) {
+	count, periodStr, ok := strings.Cut(s, "/")
+	if !ok {
+		return Spec{}, fmt.Errorf("invalid rate limit spec %q: expected format N/duration", s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || n <= 0 {
+		return Spec{}, fmt.Errorf("invalid rate limit spec %q: count must be a positive integer", s)
+	}
+
+	period, err := time.ParseDuration(strings.TrimSpace(periodStr))
+	if err != nil || period <= 0 {
+		return Spec{}, fmt.Errorf("invalid rate limit spec %q: invalid duration: %w", s, err)
+	}
+
+	return Spec{Count: n, Period: period}, nil
+}
+
+// PerSecond returns the spec's count expressed as a rate in tokens per
+// second, for use as a token-bucket refill rate.
+func (s Spec) PerSecond() float64 {
+	return float64(s.Count) / s.Period.Seconds()
+}
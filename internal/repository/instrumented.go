@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"go-apibuilder/db/sqlc"
+	"go-apibuilder/internal/telemetry"
+)
+
+var _ UserRepository = (*instrumentedUserRepository)(nil)
+
+// instrumentedUserRepository decorates a UserRepository with spans and
+// repository_calls_total/repository_call_duration_seconds metrics for every
+// method, via telemetry.InstrumentRepositoryCall.
+type instrumentedUserRepository struct {
+	next UserRepository
+}
+
+// Instrument wraps repo so each call is recorded as an OpenTelemetry span
+// and a Prometheus metric. Wire it in main.go around the repository
+// returned by NewDBUserRepository, before it is handed to the service
+// layer.
+func Instrument(repo UserRepository) UserRepository {
+	return &instrumentedUserRepository{next: repo}
+}
+
+func (i *instrumentedUserRepository) CreateUser(ctx context.Context, arg sqlc.CreateUserParams) (sqlc.User, error) {
+	return telemetry.InstrumentRepositoryCall(ctx, "CreateUser", func(ctx context.Context) (sqlc.User, error) {
+		return i.next.CreateUser(ctx, arg)
+	})
+}
+
+func (i *instrumentedUserRepository) DeleteUser(ctx context.Context, id int64) error {
+	return telemetry.InstrumentRepositoryCallErr(ctx, "DeleteUser", func(ctx context.Context) error {
+		return i.next.DeleteUser(ctx, id)
+	})
+}
+
+func (i *instrumentedUserRepository) GetUserByID(ctx context.Context, id int64) (sqlc.User, error) {
+	return telemetry.InstrumentRepositoryCall(ctx, "GetUserByID", func(ctx context.Context) (sqlc.User, error) {
+		return i.next.GetUserByID(ctx, id)
+	})
+}
+
+func (i *instrumentedUserRepository) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
+	return telemetry.InstrumentRepositoryCall(ctx, "GetUserByEmail", func(ctx context.Context) (sqlc.User, error) {
+		return i.next.GetUserByEmail(ctx, email)
+	})
+}
+
+func (i *instrumentedUserRepository) ListUsers(ctx context.Context, arg sqlc.ListUsersParams) ([]sqlc.User, error) {
+	return telemetry.InstrumentRepositoryCall(ctx, "ListUsers", func(ctx context.Context) ([]sqlc.User, error) {
+		return i.next.ListUsers(ctx, arg)
+	})
+}
+
+func (i *instrumentedUserRepository) UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.User, error) {
+	return telemetry.InstrumentRepositoryCall(ctx, "UpdateUser", func(ctx context.Context) (sqlc.User, error) {
+		return i.next.UpdateUser(ctx, arg)
+	})
+}
+
+func (i *instrumentedUserRepository) RehashIfNeeded(ctx context.Context, user sqlc.User, password string) (sqlc.User, error) {
+	return telemetry.InstrumentRepositoryCall(ctx, "RehashIfNeeded", func(ctx context.Context) (sqlc.User, error) {
+		return i.next.RehashIfNeeded(ctx, user, password)
+	})
+}
@@ -2,12 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"go-apibuilder/db/sqlc"
 	"go-apibuilder/internal/util"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// ErrUserNotFound is returned by GetUserByEmail when no user matches,
+// distinguishing "no such user" from an underlying repository error so
+// callers such as the OIDC login flow can tell whether to provision a new
+// user or surface a failure.
+var ErrUserNotFound = errors.New("user not found")
+
 var _ UserRepository = (*DBUserRepository)(nil)
 
 type UserRepository interface {
@@ -17,6 +25,17 @@ type UserRepository interface {
 	GetUserByEmail(ctx context.Context, email string) (sqlc.User, error)
 	ListUsers(ctx context.Context, arg sqlc.ListUsersParams) ([]sqlc.User, error)
 	UpdateUser(ctx context.Context, arg sqlc.UpdateUserParams) (sqlc.User, error)
+	RehashIfNeeded(ctx context.Context, user sqlc.User, password string) (sqlc.User, error)
+}
+
+// ExternalIdentityLinker will back future account-link tables associating a
+// local user with one or more external OIDC identities (provider + subject
+// pair). internal/auth/oidc currently resolves external logins by email
+// alone and does not require a repository to implement this; it is defined
+// ahead of that work so callers can start depending on the interface.
+type ExternalIdentityLinker interface {
+	LinkExternalIdentity(ctx context.Context, userID int64, provider, subject string) error
+	FindUserByExternalIdentity(ctx context.Context, provider, subject string) (sqlc.User, error)
 }
 
 func NewDBUserRepository(querier sqlc.Querier) UserRepository {
@@ -44,9 +63,14 @@ func (d *DBUserRepository) DeleteUser(ctx context.Context, id int64) error {
 	return d.db.DeleteUser(ctx, id)
 }
 
-// GetUserByEmail implements UserRepository.
+// GetUserByEmail implements UserRepository. It returns ErrUserNotFound,
+// rather than the underlying pgx.ErrNoRows, when no user has that email.
 func (d *DBUserRepository) GetUserByEmail(ctx context.Context, email string) (sqlc.User, error) {
-	return d.db.GetUserByEmail(ctx, email)
+	user, err := d.db.GetUserByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.User{}, ErrUserNotFound
+	}
+	return user, err
 }
 
 // GetUserByID implements UserRepository.
@@ -77,3 +101,25 @@ func (d *DBUserRepository) UpdateUser(ctx context.Context, arg sqlc.UpdateUserPa
 
 	return d.db.UpdateUser(ctx, arg)
 }
+
+// RehashIfNeeded re-hashes and persists a user's password with the current
+// util.DefaultAlgorithm and parameters when the hash they logged in with is
+// stale - the equivalent of bcrypt's NeedsRehash. password is the plaintext
+// password that was just verified by util.CheckPasswordHash; callers should
+// only invoke this after a successful login. If the existing hash is
+// already up to date, user is returned unchanged.
+func (d *DBUserRepository) RehashIfNeeded(ctx context.Context, user sqlc.User, password string) (sqlc.User, error) {
+	if !util.NeedsRehash(user.HashedPassword) {
+		return user, nil
+	}
+
+	newHashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		return sqlc.User{}, err
+	}
+
+	return d.db.UpdateUser(ctx, sqlc.UpdateUserParams{
+		ID:             user.ID,
+		HashedPassword: pgtype.Text{String: newHashedPassword, Valid: true},
+	})
+}
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"go-apibuilder/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAuthRoutes registers the password-login JWT endpoints under
+// apiGroup: POST /auth/login, /auth/refresh, and /auth/logout.
+func SetupAuthRoutes(apiGroup *gin.RouterGroup, authHandler *handler.AuthHandler) {
+	authRoutes := apiGroup.Group("/auth")
+	{
+		authRoutes.POST("/login", authHandler.Login)
+		authRoutes.POST("/refresh", authHandler.Refresh)
+		authRoutes.POST("/logout", authHandler.Logout)
+	}
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"go-apibuilder/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOIDCRoutes registers the "login with <provider>" endpoints under
+// apiGroup: GET /auth/oidc/:provider/login and /auth/oidc/:provider/callback.
+func SetupOIDCRoutes(apiGroup *gin.RouterGroup, oidcHandler *handler.OIDCHandler) {
+	oidcRoutes := apiGroup.Group("/auth/oidc")
+	{
+		oidcRoutes.GET("/:provider/login", oidcHandler.Login)
+		oidcRoutes.GET("/:provider/callback", oidcHandler.Callback)
+	}
+}
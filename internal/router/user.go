@@ -1,15 +1,20 @@
 package router
 
 import (
+	"go-apibuilder/internal/auth"
 	"go-apibuilder/internal/handler"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupUserRoutes(apiGroup *gin.RouterGroup, userHandler *handler.UserHandler) {
+// SetupUserRoutes registers the user CRUD endpoints under apiGroup.
+// GET /users/:id requires a valid, non-revoked session via
+// auth.RequireAuth; authService is nil-safe only in the sense that callers
+// must always provide one now that this route is authenticated.
+func SetupUserRoutes(apiGroup *gin.RouterGroup, userHandler *handler.UserHandler, authService *auth.Service) {
 	userRoutes := apiGroup.Group("/users")
 	{
 		userRoutes.POST("", userHandler.CreateUser)
-		userRoutes.GET("/:id", userHandler.GetUserByID)
+		userRoutes.GET("/:id", auth.RequireAuth(authService), userHandler.GetUserByID)
 	}
 }
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"go-apibuilder/db/sqlc"
+	"go-apibuilder/internal/telemetry"
+)
+
+var _ UserService = (*instrumentedUserService)(nil)
+
+// instrumentedUserService decorates a UserService with spans and
+// service_calls_total/service_call_duration_seconds metrics for every
+// method, via telemetry.InstrumentServiceCall.
+type instrumentedUserService struct {
+	next UserService
+}
+
+// Instrument wraps svc so each call is recorded as an OpenTelemetry span
+// and a Prometheus metric. Wire it in main.go around the service returned
+// by NewUserService, before it is handed to handlers and the gRPC server.
+func Instrument(svc UserService) UserService {
+	return &instrumentedUserService{next: svc}
+}
+
+func (i *instrumentedUserService) CreateUser(ctx context.Context, params sqlc.CreateUserParams) (sqlc.User, error) {
+	return telemetry.InstrumentServiceCall(ctx, "CreateUser", func(ctx context.Context) (sqlc.User, error) {
+		return i.next.CreateUser(ctx, params)
+	})
+}
+
+func (i *instrumentedUserService) GetUserByID(ctx context.Context, id int64) (sqlc.User, error) {
+	return telemetry.InstrumentServiceCall(ctx, "GetUserByID", func(ctx context.Context) (sqlc.User, error) {
+		return i.next.GetUserByID(ctx, id)
+	})
+}
+
+func (i *instrumentedUserService) ListUsers(ctx context.Context, params sqlc.ListUsersParams) ([]sqlc.User, error) {
+	return telemetry.InstrumentServiceCall(ctx, "ListUsers", func(ctx context.Context) ([]sqlc.User, error) {
+		return i.next.ListUsers(ctx, params)
+	})
+}
+
+func (i *instrumentedUserService) UpdateUser(ctx context.Context, params sqlc.UpdateUserParams) (sqlc.User, error) {
+	return telemetry.InstrumentServiceCall(ctx, "UpdateUser", func(ctx context.Context) (sqlc.User, error) {
+		return i.next.UpdateUser(ctx, params)
+	})
+}
+
+func (i *instrumentedUserService) DeleteUser(ctx context.Context, id int64) error {
+	return telemetry.InstrumentServiceCallErr(ctx, "DeleteUser", func(ctx context.Context) error {
+		return i.next.DeleteUser(ctx, id)
+	})
+}
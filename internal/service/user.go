@@ -9,6 +9,9 @@ import (
 type UserService interface {
 	CreateUser(ctx context.Context, params sqlc.CreateUserParams) (sqlc.User, error)
 	GetUserByID(ctx context.Context, id int64) (sqlc.User, error)
+	ListUsers(ctx context.Context, params sqlc.ListUsersParams) ([]sqlc.User, error)
+	UpdateUser(ctx context.Context, params sqlc.UpdateUserParams) (sqlc.User, error)
+	DeleteUser(ctx context.Context, id int64) error
 }
 
 type userServiceImplementation struct {
@@ -26,3 +29,15 @@ func (s *userServiceImplementation) CreateUser(ctx context.Context, params sqlc.
 func (s *userServiceImplementation) GetUserByID(ctx context.Context, id int64) (sqlc.User, error) {
 	return s.userRepo.GetUserByID(ctx, id)
 }
+
+func (s *userServiceImplementation) ListUsers(ctx context.Context, params sqlc.ListUsersParams) ([]sqlc.User, error) {
+	return s.userRepo.ListUsers(ctx, params)
+}
+
+func (s *userServiceImplementation) UpdateUser(ctx context.Context, params sqlc.UpdateUserParams) (sqlc.User, error) {
+	return s.userRepo.UpdateUser(ctx, params)
+}
+
+func (s *userServiceImplementation) DeleteUser(ctx context.Context, id int64) error {
+	return s.userRepo.DeleteUser(ctx, id)
+}
@@ -0,0 +1,138 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total HTTP requests, labeled by method, route template, and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by method and route template.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	repositoryCallsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "repository_calls_total",
+		Help: "Total repository calls, labeled by method and result (ok/error).",
+	}, []string{"method", "result"})
+
+	repositoryCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "repository_call_duration_seconds",
+		Help:    "Repository call latency in seconds, labeled by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	serviceCallsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "service_calls_total",
+		Help: "Total service calls, labeled by method and result (ok/error).",
+	}, []string{"method", "result"})
+
+	serviceCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "service_call_duration_seconds",
+		Help:    "Service call latency in seconds, labeled by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+)
+
+// Handler returns the Prometheus scrape handler, meant to be registered on
+// /metrics outside the /api/v1 group.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// GinMiddleware records http_requests_total and http_request_duration_seconds
+// for every request, labeled by the matched route template (not the raw
+// path, so e.g. "/users/:id" doesn't create a series per user ID).
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		httpRequestsTotal.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpRequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// instrumentCall is the shared implementation behind InstrumentRepositoryCall
+// and InstrumentServiceCall: it starts a span named spanName, runs fn, then
+// records a counter/histogram pair labeled by method and ok/error result.
+func instrumentCall[T any](
+	ctx context.Context,
+	tracer trace.Tracer,
+	spanName, method string,
+	calls *prometheus.CounterVec,
+	duration *prometheus.HistogramVec,
+	fn func(ctx context.Context) (T, error),
+) (T, error) {
+	ctx, span := tracer.Start(ctx, spanName)
+	defer span.End()
+
+	start := time.Now()
+	result, err := fn(ctx)
+
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+		span.RecordError(err)
+	}
+
+	calls.WithLabelValues(method, outcome).Inc()
+	duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+
+	return result, err
+}
+
+var (
+	repositoryTracer = otel.Tracer("go-apibuilder/repository")
+	serviceTracer    = otel.Tracer("go-apibuilder/service")
+)
+
+// InstrumentRepositoryCall wraps a repository method call with a span and
+// repository_calls_total/repository_call_duration_seconds metrics.
+func InstrumentRepositoryCall[T any](ctx context.Context, method string, fn func(ctx context.Context) (T, error)) (T, error) {
+	return instrumentCall(ctx, repositoryTracer, "repository."+method, method, repositoryCallsTotal, repositoryCallDuration, fn)
+}
+
+// InstrumentRepositoryCallErr is InstrumentRepositoryCall for methods that
+// return only an error.
+func InstrumentRepositoryCallErr(ctx context.Context, method string, fn func(ctx context.Context) error) error {
+	_, err := InstrumentRepositoryCall(ctx, method, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// InstrumentServiceCall wraps a service method call with a span and
+// service_calls_total/service_call_duration_seconds metrics.
+func InstrumentServiceCall[T any](ctx context.Context, method string, fn func(ctx context.Context) (T, error)) (T, error) {
+	return instrumentCall(ctx, serviceTracer, "service."+method, method, serviceCallsTotal, serviceCallDuration, fn)
+}
+
+// InstrumentServiceCallErr is InstrumentServiceCall for methods that return
+// only an error.
+func InstrumentServiceCallErr(ctx context.Context, method string, fn func(ctx context.Context) error) error {
+	_, err := InstrumentServiceCall(ctx, method, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
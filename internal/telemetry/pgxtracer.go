@@ -0,0 +1,45 @@
+package telemetry
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var dbTracer = otel.Tracer("go-apibuilder/db")
+
+type pgxTracerSpanKey struct{}
+
+// PgxTracer implements pgx.QueryTracer, creating one span per query with
+// the query's SQL text (no bound parameter values, since those may hold
+// sensitive data) as an attribute.
+type PgxTracer struct{}
+
+// NewPgxTracer returns a pgx.QueryTracer that reports each query as a span
+// under the "go-apibuilder/db" tracer, for wiring into
+// pgxpool.Config.ConnConfig.Tracer.
+func NewPgxTracer() *PgxTracer {
+	return &PgxTracer{}
+}
+
+func (t *PgxTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, span := dbTracer.Start(ctx, "db.query", trace.WithAttributes(
+		attribute.String("db.statement", data.SQL),
+	))
+	return context.WithValue(ctx, pgxTracerSpanKey{}, span)
+}
+
+func (t *PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(pgxTracerSpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+	}
+}
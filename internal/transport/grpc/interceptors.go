@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"go-apibuilder/internal/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var errMissingBearerToken = errors.New("missing bearer token")
+
+// UnaryLoggingInterceptor logs each unary call's method, duration, and
+// resulting status code - the gRPC equivalent of gin.Logger() for the HTTP
+// side.
+func UnaryLoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		log.Printf("grpc: method=%s duration=%s status=%s", info.FullMethod, time.Since(start), status.Code(err))
+
+		return resp, err
+	}
+}
+
+// UnaryRecoveryInterceptor recovers a panicking handler and turns it into
+// codes.Internal instead of crashing the server, mirroring gin.Recovery()
+// on the HTTP side.
+func UnaryRecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("grpc: panic in %s: %v", info.FullMethod, r)
+				err = status.Errorf(codes.Internal, "internal error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// authContextUserIDKey is the context key UnaryAuthInterceptor stores the
+// authenticated user's ID under, mirroring auth.ContextUserIDKey on the
+// Gin side.
+type authContextUserIDKey struct{}
+
+// publicMethods lists the full gRPC method names left unauthenticated,
+// mirroring POST /users being left off auth.RequireAuth in
+// internal/router/user.go: signup has to be reachable without a session.
+var publicMethods = map[string]bool{
+	"/user.v1.UserService/CreateUser": true,
+}
+
+// UnaryAuthInterceptor verifies the bearer token in the "authorization"
+// metadata, rejecting the call unless its session is still alive, by
+// reusing the same auth.Service that backs RequireAuth on the HTTP side.
+// CreateUser is exempted via publicMethods since it is the signup RPC.
+func UnaryAuthInterceptor(authService *auth.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		token, err := bearerTokenFromMetadata(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		claims, err := authService.Authenticate(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired session")
+		}
+
+		ctx = context.WithValue(ctx, authContextUserIDKey{}, claims.UserID())
+		return handler(ctx, req)
+	}
+}
+
+func bearerTokenFromMetadata(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errMissingBearerToken
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", errMissingBearerToken
+	}
+
+	const prefix = "Bearer "
+	if !strings.HasPrefix(values[0], prefix) {
+		return "", errMissingBearerToken
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(values[0], prefix))
+	if token == "" {
+		return "", errMissingBearerToken
+	}
+
+	return token, nil
+}
@@ -0,0 +1,117 @@
+//go:build protogen
+
+// This file depends on the generated pkg/proto/user/v1 stubs, which are not
+// committed yet (only user.proto is). It is gated behind the protogen build
+// tag so `go build ./...`/`go vet ./...` succeed without them; once `make
+// proto-gen` output lands, drop this constraint.
+
+package grpc
+
+import (
+	"context"
+
+	"go-apibuilder/db/sqlc"
+	"go-apibuilder/internal/service"
+	userv1 "go-apibuilder/pkg/proto/user/v1"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements userv1.UserServiceServer by delegating to
+// service.UserService, so gRPC and the Gin/HTTP handlers share the same
+// business logic.
+type Server struct {
+	userv1.UnimplementedUserServiceServer
+
+	userService service.UserService
+}
+
+// NewServer creates a Server backed by the given UserService.
+func NewServer(userService service.UserService) *Server {
+	return &Server{userService: userService}
+}
+
+// CreateUser implements userv1.UserServiceServer.
+func (s *Server) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.User, error) {
+	user, err := s.userService.CreateUser(ctx, sqlc.CreateUserParams{
+		Email:          req.GetEmail(),
+		HashedPassword: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
+	}
+
+	return toProtoUser(user), nil
+}
+
+// GetUserByID implements userv1.UserServiceServer.
+func (s *Server) GetUserByID(ctx context.Context, req *userv1.GetUserByIDRequest) (*userv1.User, error) {
+	user, err := s.userService.GetUserByID(ctx, req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+	}
+
+	return toProtoUser(user), nil
+}
+
+// ListUsers implements userv1.UserServiceServer.
+func (s *Server) ListUsers(ctx context.Context, req *userv1.ListUsersRequest) (*userv1.ListUsersResponse, error) {
+	users, err := s.userService.ListUsers(ctx, sqlc.ListUsersParams{
+		Limit:  req.GetLimit(),
+		Offset: req.GetOffset(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+	}
+
+	protoUsers := make([]*userv1.User, 0, len(users))
+	for _, user := range users {
+		protoUsers = append(protoUsers, toProtoUser(user))
+	}
+
+	return &userv1.ListUsersResponse{Users: protoUsers}, nil
+}
+
+// UpdateUser implements userv1.UserServiceServer.
+func (s *Server) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.User, error) {
+	params := sqlc.UpdateUserParams{ID: req.GetId()}
+
+	if email := req.Email; email != nil {
+		params.Email = pgtype.Text{String: *email, Valid: true}
+	}
+	if password := req.Password; password != nil {
+		params.HashedPassword = pgtype.Text{String: *password, Valid: true}
+	}
+
+	user, err := s.userService.UpdateUser(ctx, params)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update user: %v", err)
+	}
+
+	return toProtoUser(user), nil
+}
+
+// DeleteUser implements userv1.UserServiceServer.
+func (s *Server) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*userv1.DeleteUserResponse, error) {
+	if err := s.userService.DeleteUser(ctx, req.GetId()); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete user: %v", err)
+	}
+
+	return &userv1.DeleteUserResponse{}, nil
+}
+
+func toProtoUser(user sqlc.User) *userv1.User {
+	protoUser := &userv1.User{
+		Id:    user.ID,
+		Email: user.Email,
+	}
+
+	if user.CreatedAt.Valid {
+		protoUser.CreatedAt = timestamppb.New(user.CreatedAt.Time)
+	}
+
+	return protoUser
+}
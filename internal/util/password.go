@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/pbkdf2"
 )
 
@@ -18,21 +19,44 @@ const (
 	passwordHashBytes    = 32
 	passwordIterations   = 1_000_000 // OWASP recommendation as of 2023 is 600,000 for PBKDF2-SHA256
 	passwordAlgorithmKey = "pbkdf2-sha256"
+
+	// Argon2id parameters, tuned per the OWASP cheat sheet's "memory-constrained" profile.
+	argon2AlgorithmKey = "argon2id"
+	argon2Memory       = 64 * 1024 // KiB
+	argon2Time         = 3
+	argon2Parallelism  = 2
+	argon2HashBytes    = 32
 )
 
+// DefaultAlgorithm is the algorithm HashPassword uses for newly hashed
+// passwords. It is set from config.Config.PasswordHashAlgorithm at startup;
+// CheckPasswordHash keeps verifying any algorithm it recognizes regardless
+// of this value, so changing it does not invalidate existing hashes.
+var DefaultAlgorithm = argon2AlgorithmKey
+
 // ErrInvalidHashFormat indicates that the hash string is not in the expected format.
 var ErrInvalidHashFormat = errors.New("invalid hash format")
 
-// ErrIncompatibleAlgorithm indicates that the algorithm used for hashing is not supported.
-var ErrIncompatibleAlgorithm = errors.New("incompatible algorithm")
-
-// HashPassword creates a PBKDF2 hash of the password.
-// The returned string is in the format "algorithm:iterations:salt:hash".
+// HashPassword hashes the password with DefaultAlgorithm.
+// The returned string is in the format "algorithm:params:salt:hash", where
+// params is algorithm-specific: an iteration count for pbkdf2-sha256, or
+// "m=<memory>,t=<time>,p=<parallelism>" for argon2id.
 func HashPassword(password string) (string, error) {
 	if password == "" {
 		return "", errors.New("password cannot be empty")
 	}
 
+	switch DefaultAlgorithm {
+	case argon2AlgorithmKey:
+		return hashPasswordArgon2id(password)
+	case passwordAlgorithmKey:
+		return hashPasswordPBKDF2(password)
+	default:
+		return "", fmt.Errorf("%w: %s", ErrIncompatibleAlgorithm, DefaultAlgorithm)
+	}
+}
+
+func hashPasswordPBKDF2(password string) (string, error) {
 	salt := make([]byte, passwordSaltBytes)
 	if _, err := rand.Read(salt); err != nil {
 		return "", fmt.Errorf("failed to generate salt: %w", err)
@@ -48,11 +72,29 @@ func HashPassword(password string) (string, error) {
 	return fmt.Sprintf("%s:%d:%s:%s", passwordAlgorithmKey, passwordIterations, b64Salt, b64Hash), nil
 }
 
-// CheckPasswordHash verifies a password against a stored PBKDF2 hash.
-// The storedHash is expected to be in the format "algorithm:iterations:salt:hash".
+func hashPasswordArgon2id(password string) (string, error) {
+	salt := make([]byte, passwordSaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	hash := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Parallelism, argon2HashBytes)
+
+	b64Salt := base64.RawStdEncoding.EncodeToString(salt)
+	b64Hash := base64.RawStdEncoding.EncodeToString(hash)
+
+	// Format: algorithm:m=...,t=...,p=...:salt:hash
+	params := fmt.Sprintf("m=%d,t=%d,p=%d", argon2Memory, argon2Time, argon2Parallelism)
+	return fmt.Sprintf("%s:%s:%s:%s", argon2AlgorithmKey, params, b64Salt, b64Hash), nil
+}
+
+// CheckPasswordHash verifies a password against a stored hash, dispatching
+// on the algorithm named in the hash's first field. Both "pbkdf2-sha256"
+// and "argon2id" are recognized, so existing hashes keep verifying after
+// DefaultAlgorithm changes.
 func CheckPasswordHash(password, storedHash string) (bool, error) {
 	if password == "" || storedHash == "" {
-		return false, errors.New("password and stored hash cannot be empty")
+		return false, ErrHashAndPasswordNotEmpty
 	}
 
 	parts := strings.Split(storedHash, ":")
@@ -60,11 +102,17 @@ func CheckPasswordHash(password, storedHash string) (bool, error) {
 		return false, ErrInvalidHashFormat
 	}
 
-	algorithm := parts[0]
-	if algorithm != passwordAlgorithmKey {
+	switch parts[0] {
+	case passwordAlgorithmKey:
+		return checkPasswordHashPBKDF2(password, parts)
+	case argon2AlgorithmKey:
+		return checkPasswordHashArgon2id(password, parts)
+	default:
 		return false, ErrIncompatibleAlgorithm
 	}
+}
 
+func checkPasswordHashPBKDF2(password string, parts []string) (bool, error) {
 	iterations, err := parseInt(parts[1])
 	if err != nil {
 		return false, fmt.Errorf("failed to parse iterations: %w", err)
@@ -84,11 +132,97 @@ func CheckPasswordHash(password, storedHash string) (bool, error) {
 	comparisonHash := pbkdf2.Key([]byte(password), salt, iterations, len(hash), sha256.New)
 
 	// Constant time comparison to prevent timing attacks
-	if subtle.ConstantTimeCompare(hash, comparisonHash) == 1 {
-		return true, nil
+	return subtle.ConstantTimeCompare(hash, comparisonHash) == 1, nil
+}
+
+func checkPasswordHashArgon2id(password string, parts []string) (bool, error) {
+	memory, time, parallelism, err := parseArgon2Params(parts[1])
+	if err != nil {
+		return false, err
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false, fmt.Errorf("failed to decode salt: %w", err)
+	}
+
+	hash, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, fmt.Errorf("failed to decode hash: %w", err)
+	}
+
+	comparisonHash := argon2.IDKey([]byte(password), salt, time, memory, parallelism, uint32(len(hash)))
+
+	// Constant time comparison to prevent timing attacks
+	return subtle.ConstantTimeCompare(hash, comparisonHash) == 1, nil
+}
+
+// NeedsRehash reports whether storedHash should be rehashed with the
+// current DefaultAlgorithm and parameters - either because it used a
+// different algorithm, or because its parameters are weaker than what
+// this binary currently uses. Callers typically invoke this right after a
+// successful CheckPasswordHash, mirroring bcrypt's NeedsRehash.
+func NeedsRehash(storedHash string) bool {
+	parts := strings.Split(storedHash, ":")
+	if len(parts) != 4 {
+		return true
+	}
+
+	if parts[0] != DefaultAlgorithm {
+		return true
+	}
+
+	switch parts[0] {
+	case passwordAlgorithmKey:
+		iterations, err := parseInt(parts[1])
+		if err != nil {
+			return true
+		}
+		return iterations < passwordIterations
+	case argon2AlgorithmKey:
+		memory, time, parallelism, err := parseArgon2Params(parts[1])
+		if err != nil {
+			return true
+		}
+		return memory < argon2Memory || time < argon2Time || parallelism < argon2Parallelism
+	default:
+		return true
+	}
+}
+
+// parseArgon2Params parses the "m=<memory>,t=<time>,p=<parallelism>" field
+// of an argon2id hash string.
+func parseArgon2Params(s string) (memory uint32, time uint32, parallelism uint8, err error) {
+	fields := strings.Split(s, ",")
+	if len(fields) != 3 {
+		return 0, 0, 0, ErrInvalidHashFormat
+	}
+
+	values := make(map[string]string, 3)
+	for _, field := range fields {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return 0, 0, 0, ErrInvalidHashFormat
+		}
+		values[kv[0]] = kv[1]
+	}
+
+	m, err := parseInt(values["m"])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to parse argon2 memory: %w", err)
+	}
+
+	t, err := parseInt(values["t"])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to parse argon2 time: %w", err)
+	}
+
+	p, err := parseInt(values["p"])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to parse argon2 parallelism: %w", err)
 	}
 
-	return false, nil
+	return uint32(m), uint32(t), uint8(p), nil
 }
 
 // Helper function to parse int, as strconv.Atoi is not used directly to avoid import cycle if this moves.
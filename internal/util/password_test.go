@@ -0,0 +1,135 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func withDefaultAlgorithm(t *testing.T, algorithm string) {
+	t.Helper()
+	previous := DefaultAlgorithm
+	DefaultAlgorithm = algorithm
+	t.Cleanup(func() { DefaultAlgorithm = previous })
+}
+
+func TestHashPassword_AlgorithmDispatch(t *testing.T) {
+	for _, algorithm := range []string{argon2AlgorithmKey, passwordAlgorithmKey} {
+		t.Run(algorithm, func(t *testing.T) {
+			withDefaultAlgorithm(t, algorithm)
+
+			hash, err := HashPassword("correct horse battery staple")
+			if err != nil {
+				t.Fatalf("HashPassword() error = %v", err)
+			}
+
+			parts := splitHash(t, hash)
+			if parts[0] != algorithm {
+				t.Fatalf("hash prefix = %q, want %q", parts[0], algorithm)
+			}
+
+			ok, err := CheckPasswordHash("correct horse battery staple", hash)
+			if err != nil {
+				t.Fatalf("CheckPasswordHash() error = %v", err)
+			}
+			if !ok {
+				t.Fatal("CheckPasswordHash() = false, want true for the password just hashed")
+			}
+		})
+	}
+}
+
+func TestHashPassword_UnknownAlgorithm(t *testing.T) {
+	withDefaultAlgorithm(t, "md5")
+
+	_, err := HashPassword("correct horse battery staple")
+	if !errors.Is(err, ErrIncompatibleAlgorithm) {
+		t.Fatalf("HashPassword() error = %v, want ErrIncompatibleAlgorithm", err)
+	}
+}
+
+func TestHashPassword_EmptyPassword(t *testing.T) {
+	if _, err := HashPassword(""); err == nil {
+		t.Fatal("HashPassword(\"\") error = nil, want non-nil")
+	}
+}
+
+func TestCheckPasswordHash_WrongPassword(t *testing.T) {
+	withDefaultAlgorithm(t, argon2AlgorithmKey)
+
+	hash, err := HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+
+	ok, err := CheckPasswordHash("wrong password", hash)
+	if err != nil {
+		t.Fatalf("CheckPasswordHash() error = %v", err)
+	}
+	if ok {
+		t.Fatal("CheckPasswordHash() = true, want false for a mismatched password")
+	}
+}
+
+func TestCheckPasswordHash_MalformedHash(t *testing.T) {
+	tests := map[string]string{
+		"wrong field count":        "argon2id:m=65536,t=3,p=2:c2FsdA",
+		"unknown algorithm":        "bcrypt:10:c2FsdA:aGFzaA",
+		"non-base64 salt":          "argon2id:m=65536,t=3,p=2:not-base64!!:aGFzaA",
+		"malformed argon2 params":  "argon2id:not-key-value-pairs:c2FsdA:aGFzaA",
+		"missing argon2 param key": "argon2id:m=65536,t=3:c2FsdA:aGFzaA",
+	}
+
+	for name, hash := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := CheckPasswordHash("correct horse battery staple", hash); err == nil {
+				t.Fatalf("CheckPasswordHash(%q) error = nil, want non-nil", hash)
+			}
+		})
+	}
+}
+
+func TestCheckPasswordHash_EmptyInputs(t *testing.T) {
+	if _, err := CheckPasswordHash("", "argon2id:m=65536,t=3,p=2:c2FsdA:aGFzaA"); !errors.Is(err, ErrHashAndPasswordNotEmpty) {
+		t.Fatalf("CheckPasswordHash(\"\", hash) error = %v, want ErrHashAndPasswordNotEmpty", err)
+	}
+	if _, err := CheckPasswordHash("password", ""); !errors.Is(err, ErrHashAndPasswordNotEmpty) {
+		t.Fatalf("CheckPasswordHash(password, \"\") error = %v, want ErrHashAndPasswordNotEmpty", err)
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	withDefaultAlgorithm(t, argon2AlgorithmKey)
+
+	current, err := HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	if NeedsRehash(current) {
+		t.Fatal("NeedsRehash() = true for a hash using the current algorithm and parameters, want false")
+	}
+
+	weakerParams := fmt.Sprintf("argon2id:m=%d,t=%d,p=%d:c2FsdA:aGFzaA", argon2Memory/2, argon2Time, argon2Parallelism)
+	if !NeedsRehash(weakerParams) {
+		t.Fatal("NeedsRehash() = false for weaker argon2 parameters, want true")
+	}
+
+	otherAlgorithm := "pbkdf2-sha256:1000000:c2FsdA:aGFzaA"
+	if !NeedsRehash(otherAlgorithm) {
+		t.Fatal("NeedsRehash() = false for a hash using a different algorithm than DefaultAlgorithm, want true")
+	}
+
+	if !NeedsRehash("not-a-valid-hash") {
+		t.Fatal("NeedsRehash() = false for a malformed hash, want true")
+	}
+}
+
+func splitHash(t *testing.T, hash string) []string {
+	t.Helper()
+	parts := strings.Split(hash, ":")
+	if len(parts) != 4 {
+		t.Fatalf("hash %q has %d fields, want 4", hash, len(parts))
+	}
+	return parts
+}